@@ -0,0 +1,79 @@
+// Copyright 2016-2019 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// dmidecode prints the BIOS Information table, or a single field of it.
+//
+// Synopsis:
+//
+//	dmidecode [--type N --field NAME]
+//
+// Description:
+//
+//	With no flags, dmidecode prints the decoded BIOS Information table
+//	(SMBIOS type 0) in dmidecode's usual human-readable form.
+//
+//	With --type and --field, dmidecode instead prints the single named
+//	field of that table (e.g. --type 0 --field vendor), using
+//	smbios.Query's dotted-path names.
+//
+// TODO: this only covers SMBIOS type 0; reconcile with the rest of the
+// decoded tables once this merges alongside the full dmidecode command.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/u-root/u-root/pkg/smbios"
+)
+
+var (
+	typeFlag  = flag.Int("type", -1, "SMBIOS table type to query a single field from, e.g. 0 for BIOS Information")
+	fieldFlag = flag.String("field", "", "dotted field name to print, e.g. vendor or characteristics.uefi")
+)
+
+// tableQueryPrefix maps the --type flag to the top-level name Query
+// expects, e.g. "bios" for --type 0.
+var tableQueryPrefix = map[int]string{
+	0: "bios",
+}
+
+func main() {
+	flag.Parse()
+	if err := run(os.Stdout, *typeFlag, *fieldFlag); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(w io.Writer, typ int, field string) error {
+	info, err := smbios.FromSysfs()
+	if err != nil {
+		return fmt.Errorf("reading SMBIOS tables: %w", err)
+	}
+
+	if typ < 0 && field == "" {
+		if info.BIOS != nil {
+			fmt.Fprintln(w, info.BIOS)
+		}
+		return nil
+	}
+	if typ < 0 || field == "" {
+		return fmt.Errorf("--type and --field must be given together")
+	}
+
+	prefix, ok := tableQueryPrefix[typ]
+	if !ok {
+		return fmt.Errorf("--type %d is not supported for --field queries", typ)
+	}
+
+	value, err := smbios.Query(info, prefix+"."+field)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(w, value)
+	return nil
+}
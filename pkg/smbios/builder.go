@@ -0,0 +1,226 @@
+// Copyright 2016-2019 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package smbios
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+)
+
+// Builder assembles a set of SMBIOS structures and serializes them, along
+// with their string tables, back into the raw binary form described in
+// DSP0134. It is the inverse of the parsing done by NewBIOSInformation and
+// friends.
+//
+// The zero value of Builder is ready to use.
+type Builder struct {
+	tables []*Table
+}
+
+// NewBuilder returns an empty Builder.
+func NewBuilder() *Builder {
+	return &Builder{}
+}
+
+// AddBIOSInformation encodes bi as a raw Table and appends it to b. handle
+// is the SMBIOS handle to assign to the resulting structure.
+func (b *Builder) AddBIOSInformation(bi *BIOSInformation, handle uint16) error {
+	t, err := encodeBIOSInformation(bi, handle)
+	if err != nil {
+		return err
+	}
+	b.tables = append(b.tables, t)
+	return nil
+}
+
+// AddTable appends an already-encoded Table to b, e.g. one produced by a
+// caller that only has access to the generic Table representation.
+func (b *Builder) AddTable(t *Table) {
+	b.tables = append(b.tables, t)
+}
+
+// encodeBIOSInformation serializes bi's fields into a Table, building the
+// string table and assigning 1-based string indices in field order as they
+// are first encountered, per DSP0134 6.1.3.
+func encodeBIOSInformation(bi *BIOSInformation, handle uint16) (*Table, error) {
+	st := &stringTable{}
+
+	vendor := st.add(bi.Vendor)
+	version := st.add(bi.Version)
+	releaseDate := st.add(bi.ReleaseDate)
+
+	var data bytes.Buffer
+	data.WriteByte(vendor)
+	data.WriteByte(version)
+	binary.Write(&data, binary.LittleEndian, bi.StartingAddressSegment)
+	data.WriteByte(releaseDate)
+	data.WriteByte(bi.ROMSize)
+	binary.Write(&data, binary.LittleEndian, uint64(bi.Characteristics))
+	data.WriteByte(byte(bi.CharacteristicsExt1))
+	data.WriteByte(byte(bi.CharacteristicsExt2))
+	data.WriteByte(bi.SystemBIOSMajorRelease)
+	data.WriteByte(bi.SystemBIOSMinorRelease)
+	data.WriteByte(bi.EmbeddedControllerFirmwareMajorRelease)
+	data.WriteByte(bi.EmbeddedControllerFirmwareMinorRelease)
+	binary.Write(&data, binary.LittleEndian, bi.ExtendedROMSize)
+
+	if data.Len() > 0xff-4 {
+		return nil, fmt.Errorf("BIOS Information structure too large: %d bytes", data.Len())
+	}
+
+	return &Table{
+		Header: Header{
+			Type:   TableTypeBIOSInformation,
+			Length: uint8(data.Len() + 4),
+			Handle: handle,
+		},
+		data:    data.Bytes(),
+		strings: st.strings,
+	}, nil
+}
+
+// stringTable accumulates the unformatted string-set of a single Table,
+// assigning each distinct non-empty string the next 1-based index and
+// reusing indices for repeated strings, as dmidecode and friends expect.
+type stringTable struct {
+	strings []string
+}
+
+// add returns the 1-based index of s in the table, adding it if necessary.
+// The empty string is always index 0.
+func (st *stringTable) add(s string) byte {
+	if s == "" {
+		return 0
+	}
+	for i, existing := range st.strings {
+		if existing == s {
+			return byte(i + 1)
+		}
+	}
+	st.strings = append(st.strings, s)
+	return byte(len(st.strings))
+}
+
+// Tables serializes every table added to b into the raw structure table
+// format, with no entry point. This is the format found at
+// /sys/firmware/dmi/tables/DMI; the entry point describing it is published
+// separately, at /sys/firmware/dmi/tables/smbios_entry_point, via
+// EntryPoint32 or EntryPoint64.
+func (b *Builder) Tables() ([]byte, error) {
+	var structs bytes.Buffer
+	for _, t := range b.tables {
+		raw, err := marshalTable(t)
+		if err != nil {
+			return nil, err
+		}
+		structs.Write(raw)
+	}
+	return structs.Bytes(), nil
+}
+
+// EntryPoint32 returns the SMBIOS 2.1 (32-bit) entry point structure
+// describing a structure table of tableLen bytes, in the format found at
+// /sys/firmware/dmi/tables/smbios_entry_point on 2.1 firmware.
+func (b *Builder) EntryPoint32(tableLen int) []byte {
+	return newEntryPoint32(tableLen, len(b.tables))
+}
+
+// EntryPoint64 returns the SMBIOS 3.0 (64-bit) entry point structure
+// describing a structure table of tableLen bytes, in the format found at
+// /sys/firmware/dmi/tables/smbios_entry_point on 3.0+ firmware.
+func (b *Builder) EntryPoint64(tableLen int) []byte {
+	return newEntryPoint64(tableLen)
+}
+
+// Write serializes b's tables preceded by a single SMBIOS 3.0 (64-bit)
+// entry point, matching what QEMU's -smbios file= option expects: one
+// entry point immediately followed by the structure table it describes.
+// Callers targeting /sys/firmware/dmi/tables/DMI and
+// /sys/firmware/dmi/tables/smbios_entry_point, which are separate files,
+// should use Tables and EntryPoint32/EntryPoint64 instead.
+func (b *Builder) Write() ([]byte, error) {
+	tables, err := b.Tables()
+	if err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	out.Write(b.EntryPoint64(len(tables)))
+	out.Write(tables)
+	return out.Bytes(), nil
+}
+
+// marshalTable renders a single structure followed by its string table and
+// the double-null terminator that ends it, per DSP0134 6.1.3.
+func marshalTable(t *Table) ([]byte, error) {
+	var out bytes.Buffer
+	binary.Write(&out, binary.LittleEndian, t.Header.Type)
+	out.WriteByte(t.Header.Length)
+	binary.Write(&out, binary.LittleEndian, t.Header.Handle)
+	out.Write(t.data)
+
+	if len(t.strings) == 0 {
+		out.Write([]byte{0, 0})
+		return out.Bytes(), nil
+	}
+	for _, s := range t.strings {
+		out.WriteString(s)
+		out.WriteByte(0)
+	}
+	out.WriteByte(0)
+	return out.Bytes(), nil
+}
+
+// newEntryPoint32 builds a minimal SMBIOS 2.1 (32-bit) entry point
+// structure. tableLen and numTables describe the structure table that
+// follows it; the table address is left as 0 for the caller to relocate.
+// The Entry Point Checksum (offset 0x04) and Intermediate Checksum (offset
+// 0x15) are filled in so that consumers like the Linux kernel's DMI scanner
+// and dmidecode, which reject an entry point whose checksum bytes don't sum
+// to 0 mod 256 over their declared range, will accept it.
+func newEntryPoint32(tableLen, numTables int) []byte {
+	var ep [0x1f]byte
+	copy(ep[0:4], []byte("_SM_"))
+	ep[5] = 0x1f // entry point length
+	ep[6] = 2    // SMBIOS major version
+	ep[7] = 8    // SMBIOS minor version
+	copy(ep[0x10:0x15], []byte("_DMI_"))
+	binary.LittleEndian.PutUint16(ep[0x16:], uint16(tableLen))
+	// ep[0x18:0x1c] intermediate structure table address; left 0.
+	binary.LittleEndian.PutUint16(ep[0x1c:], uint16(numTables))
+
+	ep[0x15] = checksum(ep[0x10:0x1f]) // intermediate anchor + its checksum byte
+	ep[0x04] = checksum(ep[0x00:0x1f]) // whole entry point structure
+	return ep[:]
+}
+
+// newEntryPoint64 builds a minimal SMBIOS 3.0 (64-bit) entry point
+// structure. tableLen describes the structure table that follows it; the
+// table address is left as 0 for the caller to relocate. The Entry Point
+// Checksum (offset 0x05) is filled in for the same reason as in
+// newEntryPoint32.
+func newEntryPoint64(tableLen int) []byte {
+	var ep [0x18]byte
+	copy(ep[0:5], []byte("_SM3_"))
+	ep[6] = 0x18 // entry point length
+	ep[7] = 3    // SMBIOS major version
+	ep[8] = 0    // SMBIOS minor version
+	binary.LittleEndian.PutUint32(ep[0xc:], uint32(tableLen))
+	// ep[0x10:0x18] structure table address; left 0.
+
+	ep[0x05] = checksum(ep[:])
+	return ep[:]
+}
+
+// checksum returns the byte that, appended to the sum of b, makes the sum
+// of all bytes (including the checksum byte itself, which must already be
+// 0 in b) equal 0 mod 256, per DSP0134's entry point checksum rule.
+func checksum(b []byte) byte {
+	var sum byte
+	for _, c := range b {
+		sum += c
+	}
+	return -sum
+}
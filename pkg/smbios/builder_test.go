@@ -0,0 +1,92 @@
+// Copyright 2016-2019 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package smbios
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestBuilderRoundTrip(t *testing.T) {
+	want := &BIOSInformation{
+		Vendor:                 "Acme Corp.",
+		Version:                "1.2.3",
+		ReleaseDate:            "01/02/2024",
+		ROMSize:                0x0f,
+		Characteristics:        BIOSCharacteristicsPCIIsSupported | BIOSCharacteristicsBIOSIsUpgradeableFlash,
+		CharacteristicsExt1:    BIOSCharacteristicsExt1ACPIIsSupported,
+		CharacteristicsExt2:    BIOSCharacteristicsExt2UEFISpecificationIsSupported,
+		SystemBIOSMajorRelease: 2,
+		SystemBIOSMinorRelease: 5,
+	}
+
+	table, err := encodeBIOSInformation(want, 0x0042)
+	if err != nil {
+		t.Fatalf("encodeBIOSInformation: %v", err)
+	}
+
+	got, merr := NewBIOSInformationOpts(table, ParseOptions{Lenient: true})
+	if merr != nil {
+		t.Fatalf("NewBIOSInformationOpts: %v", merr)
+	}
+
+	for _, tt := range []struct {
+		name string
+		want interface{}
+		got  interface{}
+	}{
+		{"Vendor", want.Vendor, got.Vendor},
+		{"Version", want.Version, got.Version},
+		{"ReleaseDate", want.ReleaseDate, got.ReleaseDate},
+		{"ROMSize", want.ROMSize, got.ROMSize},
+		{"Characteristics", want.Characteristics, got.Characteristics},
+		{"CharacteristicsExt1", want.CharacteristicsExt1, got.CharacteristicsExt1},
+		{"CharacteristicsExt2", want.CharacteristicsExt2, got.CharacteristicsExt2},
+		{"SystemBIOSMajorRelease", want.SystemBIOSMajorRelease, got.SystemBIOSMajorRelease},
+		{"SystemBIOSMinorRelease", want.SystemBIOSMinorRelease, got.SystemBIOSMinorRelease},
+	} {
+		if tt.want != tt.got {
+			t.Errorf("round-tripped %s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestBuilderWriteChecksums(t *testing.T) {
+	b := NewBuilder()
+	if err := b.AddBIOSInformation(&BIOSInformation{Vendor: "Acme Corp.", Version: "1.0"}, 0); err != nil {
+		t.Fatalf("AddBIOSInformation: %v", err)
+	}
+	out, err := b.Write()
+	if err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	sum := func(b []byte) byte {
+		var s byte
+		for _, c := range b {
+			s += c
+		}
+		return s
+	}
+	if s := sum(out[0x00:0x18]); s != 0 {
+		t.Errorf("64-bit entry point checksum = %d, want 0", s)
+	}
+
+	tables, err := b.Tables()
+	if err != nil {
+		t.Fatalf("Tables: %v", err)
+	}
+	if !bytes.Equal(out[0x18:], tables) {
+		t.Errorf("Write's table bytes diverged from Tables()")
+	}
+
+	ep32 := b.EntryPoint32(len(tables))
+	if s := sum(ep32[0x00:0x1f]); s != 0 {
+		t.Errorf("32-bit entry point checksum = %d, want 0", s)
+	}
+	if s := sum(ep32[0x10:0x1f]); s != 0 {
+		t.Errorf("32-bit intermediate anchor checksum = %d, want 0", s)
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright 2016-2019 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package smbios
+
+import "fmt"
+
+// Diff describes the differences between two Info snapshots, as seen by
+// Compare.
+type Diff struct {
+	// BIOSCharacteristics lists characteristic tokens set in one Info
+	// but not the other, one entry per token, e.g.
+	// "bios.characteristics.pci: present in a, missing in b" or
+	// "bios.characteristics_ext2.uefi: present in b, missing in a".
+	BIOSCharacteristics []string
+
+	// BIOSVendor, BIOSVersion, and BIOSReleaseDate are non-empty when a
+	// and b disagree on the corresponding field.
+	BIOSVendor      string
+	BIOSVersion     string
+	BIOSReleaseDate string
+}
+
+// IsEmpty reports whether d contains no differences.
+func (d Diff) IsEmpty() bool {
+	return len(d.BIOSCharacteristics) == 0 && d.BIOSVendor == "" && d.BIOSVersion == "" && d.BIOSReleaseDate == ""
+}
+
+// Compare returns the differences between a and b's BIOS Information
+// tables. A nil *Info, or a nil BIOS table on either side, is treated as
+// having no characteristics set and empty string fields.
+func Compare(a, b *Info) Diff {
+	var d Diff
+	var av, bv BIOSInformation
+	if a != nil && a.BIOS != nil {
+		av = *a.BIOS
+	}
+	if b != nil && b.BIOS != nil {
+		bv = *b.BIOS
+	}
+
+	d.BIOSCharacteristics = append(d.BIOSCharacteristics, diffTokens("bios.characteristics", av.Characteristics.Tokens(), bv.Characteristics.Tokens())...)
+	d.BIOSCharacteristics = append(d.BIOSCharacteristics, diffTokens("bios.characteristics_ext1", av.CharacteristicsExt1.Tokens(), bv.CharacteristicsExt1.Tokens())...)
+	d.BIOSCharacteristics = append(d.BIOSCharacteristics, diffTokens("bios.characteristics_ext2", av.CharacteristicsExt2.Tokens(), bv.CharacteristicsExt2.Tokens())...)
+
+	if av.Vendor != bv.Vendor {
+		d.BIOSVendor = fmt.Sprintf("%q != %q", av.Vendor, bv.Vendor)
+	}
+	if av.Version != bv.Version {
+		d.BIOSVersion = fmt.Sprintf("%q != %q", av.Version, bv.Version)
+	}
+	if av.ReleaseDate != bv.ReleaseDate {
+		d.BIOSReleaseDate = fmt.Sprintf("%q != %q", av.ReleaseDate, bv.ReleaseDate)
+	}
+	return d
+}
+
+// diffTokens returns the tokens present in exactly one of a and b,
+// prefixed with prefix for context.
+func diffTokens(prefix string, a, b []string) []string {
+	inA := map[string]bool{}
+	for _, t := range a {
+		inA[t] = true
+	}
+	inB := map[string]bool{}
+	for _, t := range b {
+		inB[t] = true
+	}
+	var diffs []string
+	for _, t := range a {
+		if !inB[t] {
+			diffs = append(diffs, fmt.Sprintf("%s.%s: present in a, missing in b", prefix, t))
+		}
+	}
+	for _, t := range b {
+		if !inA[t] {
+			diffs = append(diffs, fmt.Sprintf("%s.%s: present in b, missing in a", prefix, t))
+		}
+	}
+	return diffs
+}
+
+// Policy describes the minimum BIOS Information requirements a machine
+// must meet to pass CheckPolicy, e.g. for fleet-management attestation.
+type Policy struct {
+	// RequireCharacteristics lists characteristic tokens (as returned by
+	// BIOSCharacteristics.Tokens and friends, e.g. "uefi",
+	// "upgradeable", "boot_from_cd") that must be set.
+	RequireCharacteristics []string
+
+	// ForbidCharacteristics lists characteristic tokens that must not
+	// be set, e.g. "vm" to require bare metal.
+	ForbidCharacteristics []string
+
+	// MinBIOSMajorRelease and MinBIOSMinorRelease give the minimum
+	// acceptable SystemBIOSMajorRelease.SystemBIOSMinorRelease. Ignored
+	// if both are zero.
+	MinBIOSMajorRelease uint8
+	MinBIOSMinorRelease uint8
+}
+
+// Finding describes a single policy requirement that was not met.
+type Finding struct {
+	Rule    string
+	Details string
+}
+
+// CheckPolicy verifies info's BIOS Information table against policy,
+// returning one Finding per unmet requirement. A nil or empty result
+// means info is compliant.
+func CheckPolicy(info *Info, policy Policy) []Finding {
+	var findings []Finding
+	if info == nil || info.BIOS == nil {
+		return []Finding{{Rule: "bios_information_present", Details: "no BIOS Information table present"}}
+	}
+	bi := info.BIOS
+
+	set := map[string]bool{}
+	for _, t := range bi.Characteristics.Tokens() {
+		set[t] = true
+	}
+	for _, t := range bi.CharacteristicsExt1.Tokens() {
+		set[t] = true
+	}
+	for _, t := range bi.CharacteristicsExt2.Tokens() {
+		set[t] = true
+	}
+
+	for _, want := range policy.RequireCharacteristics {
+		if !set[want] {
+			findings = append(findings, Finding{Rule: "require_characteristic", Details: want})
+		}
+	}
+	for _, forbidden := range policy.ForbidCharacteristics {
+		if set[forbidden] {
+			findings = append(findings, Finding{Rule: "forbid_characteristic", Details: forbidden})
+		}
+	}
+
+	if policy.MinBIOSMajorRelease != 0 || policy.MinBIOSMinorRelease != 0 {
+		have := uint16(bi.SystemBIOSMajorRelease)<<8 | uint16(bi.SystemBIOSMinorRelease)
+		want := uint16(policy.MinBIOSMajorRelease)<<8 | uint16(policy.MinBIOSMinorRelease)
+		if have < want {
+			findings = append(findings, Finding{
+				Rule: "min_bios_release",
+				Details: fmt.Sprintf("have %d.%d, require at least %d.%d",
+					bi.SystemBIOSMajorRelease, bi.SystemBIOSMinorRelease,
+					policy.MinBIOSMajorRelease, policy.MinBIOSMinorRelease),
+			})
+		}
+	}
+
+	return findings
+}
@@ -0,0 +1,141 @@
+// Copyright 2016-2019 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package smbios
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ParseOptions controls how a Table is decoded into a typed structure.
+type ParseOptions struct {
+	// Lenient decodes every field of a short or otherwise inconsistent
+	// structure that can be decoded, instead of silently zero-filling
+	// the rest, collecting one error per field it could not decode in
+	// a MultiError. Required fields (those within Table.Len() >= 0x12)
+	// are always present in the result.
+	Lenient bool
+}
+
+// MultiError collects the errors encountered while decoding a table in
+// Lenient mode. A non-nil MultiError does not mean the decoded structure
+// is unusable; check which fields it names.
+type MultiError []error
+
+// Error implements error.
+func (m MultiError) Error() string {
+	var s []string
+	for _, err := range m {
+		s = append(s, err.Error())
+	}
+	return strings.Join(s, "; ")
+}
+
+// NewBIOSInformationOpts parses a generic Table into a BIOSInformation
+// using opts. With opts.Lenient, a short or vendor-buggy structure still
+// yields a usable *BIOSInformation, with one error per field that could
+// not be decoded returned in a MultiError, rather than the first such
+// field aborting decoding of the rest as NewBIOSInformation does.
+func NewBIOSInformationOpts(t *Table, opts ParseOptions) (*BIOSInformation, *MultiError) {
+	if t.Type != TableTypeBIOSInformation {
+		me := MultiError{fmt.Errorf("invalid table type %d", t.Type)}
+		return nil, &me
+	}
+	if t.Len() < 0x12 {
+		me := MultiError{errors.New("required fields missing")}
+		return nil, &me
+	}
+	if !opts.Lenient {
+		bi, err := NewBIOSInformation(t)
+		if err != nil {
+			me := MultiError{err}
+			return nil, &me
+		}
+		return bi, nil
+	}
+	return decodeBIOSInformationLenient(t)
+}
+
+// Byte offsets of BIOSInformation's optional fields, relative to the
+// start of Table.data (i.e. DSP0134's absolute offset minus the 4-byte
+// structure header). Structures at or above DSP0134 7.1's required
+// minimum (Table.Len() >= 0x12) may still predate any of these.
+const (
+	offCharacteristicsExt1    = 0x12 - 0x04
+	offCharacteristicsExt2    = 0x13 - 0x04
+	offSystemBIOSMajorRelease = 0x14 - 0x04
+	offSystemBIOSMinorRelease = 0x15 - 0x04
+	offECFirmwareMajorRelease = 0x16 - 0x04
+	offECFirmwareMinorRelease = 0x17 - 0x04
+	offExtendedROMSize        = 0x18 - 0x04
+)
+
+// decodeBIOSInformationLenient decodes every field of t's required and
+// optional ranges that is actually present, recording one MultiError
+// entry for each optional field truncated by a short structure and each
+// string field whose index does not resolve in t's string table.
+func decodeBIOSInformationLenient(t *Table) (*BIOSInformation, *MultiError) {
+	bi := &BIOSInformation{Table: *t}
+	d := t.data
+	var errs MultiError
+
+	str := func(name string, off int) string {
+		idx := d[off]
+		if idx == 0 {
+			return ""
+		}
+		if int(idx) > len(t.strings) {
+			errs = append(errs, fmt.Errorf("%s: string index %d out of range", name, idx))
+			return ""
+		}
+		return t.strings[idx-1]
+	}
+	optional := func(name string, off, size int, fill func()) {
+		if off+size > len(d) {
+			errs = append(errs, fmt.Errorf("%s: not present in a %d-byte structure", name, t.Len()))
+			return
+		}
+		fill()
+	}
+
+	// Required fields (guaranteed present: t.Len() >= 0x12 checked by
+	// the caller).
+	bi.Vendor = str("Vendor", 0)
+	bi.Version = str("Version", 1)
+	bi.StartingAddressSegment = binary.LittleEndian.Uint16(d[2:4])
+	bi.ReleaseDate = str("ReleaseDate", 4)
+	bi.ROMSize = d[5]
+	bi.Characteristics = BIOSCharacteristics(binary.LittleEndian.Uint64(d[6:14]))
+
+	// Optional fields, added in later DSP0134 revisions.
+	optional("CharacteristicsExt1", offCharacteristicsExt1, 1, func() {
+		bi.CharacteristicsExt1 = BIOSCharacteristicsExt1(d[offCharacteristicsExt1])
+	})
+	optional("CharacteristicsExt2", offCharacteristicsExt2, 1, func() {
+		bi.CharacteristicsExt2 = BIOSCharacteristicsExt2(d[offCharacteristicsExt2])
+	})
+	optional("SystemBIOSMajorRelease", offSystemBIOSMajorRelease, 1, func() {
+		bi.SystemBIOSMajorRelease = d[offSystemBIOSMajorRelease]
+	})
+	optional("SystemBIOSMinorRelease", offSystemBIOSMinorRelease, 1, func() {
+		bi.SystemBIOSMinorRelease = d[offSystemBIOSMinorRelease]
+	})
+	optional("EmbeddedControllerFirmwareMajorRelease", offECFirmwareMajorRelease, 1, func() {
+		bi.EmbeddedControllerFirmwareMajorRelease = d[offECFirmwareMajorRelease]
+	})
+	optional("EmbeddedControllerFirmwareMinorRelease", offECFirmwareMinorRelease, 1, func() {
+		bi.EmbeddedControllerFirmwareMinorRelease = d[offECFirmwareMinorRelease]
+	})
+	optional("ExtendedROMSize", offExtendedROMSize, 2, func() {
+		bi.ExtendedROMSize = binary.LittleEndian.Uint16(d[offExtendedROMSize : offExtendedROMSize+2])
+	})
+
+	if len(errs) == 0 {
+		return bi, nil
+	}
+	return bi, &errs
+}
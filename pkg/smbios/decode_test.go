@@ -0,0 +1,44 @@
+// Copyright 2016-2019 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package smbios
+
+import "testing"
+
+func TestNewBIOSInformationOptsLenientTruncated(t *testing.T) {
+	// A 2.0-era, 0x12-byte structure: only the required fields are
+	// present, and the Vendor string index (9) doesn't resolve in the
+	// single-entry string table below.
+	table := &Table{
+		Header:  Header{Type: TableTypeBIOSInformation, Length: 0x12},
+		data:    []byte{9, 1, 0, 0, 1, 0x0f, 0, 0, 0, 0, 0, 0, 0, 0},
+		strings: []string{"1.0"},
+	}
+
+	bi, merr := NewBIOSInformationOpts(table, ParseOptions{Lenient: true})
+	if bi == nil {
+		t.Fatal("NewBIOSInformationOpts returned nil *BIOSInformation")
+	}
+	if merr == nil {
+		t.Fatal("expected a non-nil MultiError for a truncated, malformed structure")
+	}
+	// One bad string index (Vendor) plus the seven fields added after
+	// DSP0134's required 0x12-byte minimum.
+	if len(*merr) != 8 {
+		t.Errorf("got %d collected errors, want 8: %v", len(*merr), *merr)
+	}
+
+	if bi.Vendor != "" {
+		t.Errorf("Vendor = %q, want empty (bad string index)", bi.Vendor)
+	}
+	if bi.Version != "1.0" {
+		t.Errorf("Version = %q, want %q", bi.Version, "1.0")
+	}
+	if bi.ROMSize != 0x0f {
+		t.Errorf("ROMSize = %#x, want 0x0f", bi.ROMSize)
+	}
+	if bi.CharacteristicsExt1 != 0 || bi.SystemBIOSMajorRelease != 0 {
+		t.Errorf("fields past the structure's end should be left zero")
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright 2016-2019 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package smbios
+
+import "encoding/json"
+
+// Info aggregates the decoded tables of a single SMBIOS dump.
+type Info struct {
+	BIOS *BIOSInformation
+}
+
+// biosInformationJSON mirrors BIOSInformation for JSON/YAML purposes,
+// replacing the bit-field Characteristics* types with stable string tokens
+// and the raw ROMSize/ExtendedROMSize pair with the decoded byte count.
+type biosInformationJSON struct {
+	Vendor                                 string   `json:"vendor" yaml:"vendor"`
+	Version                                string   `json:"version" yaml:"version"`
+	ReleaseDate                            string   `json:"release_date" yaml:"release_date"`
+	ROMSizeBytes                           uint     `json:"rom_size_bytes" yaml:"rom_size_bytes"`
+	Characteristics                        []string `json:"characteristics" yaml:"characteristics"`
+	CharacteristicsExt1                    []string `json:"characteristics_ext1" yaml:"characteristics_ext1"`
+	CharacteristicsExt2                    []string `json:"characteristics_ext2" yaml:"characteristics_ext2"`
+	SystemBIOSMajorRelease                 uint8    `json:"system_bios_major_release" yaml:"system_bios_major_release"`
+	SystemBIOSMinorRelease                 uint8    `json:"system_bios_minor_release" yaml:"system_bios_minor_release"`
+	EmbeddedControllerFirmwareMajorRelease uint8    `json:"ec_firmware_major_release" yaml:"ec_firmware_major_release"`
+	EmbeddedControllerFirmwareMinorRelease uint8    `json:"ec_firmware_minor_release" yaml:"ec_firmware_minor_release"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (bi *BIOSInformation) MarshalJSON() ([]byte, error) {
+	return json.Marshal(bi.toJSON())
+}
+
+// MarshalYAML implements yaml.Marshaler (gopkg.in/yaml.v3 and compatible
+// packages call this method if present, without requiring an import here).
+func (bi *BIOSInformation) MarshalYAML() (interface{}, error) {
+	return bi.toJSON(), nil
+}
+
+func (bi *BIOSInformation) toJSON() *biosInformationJSON {
+	return &biosInformationJSON{
+		Vendor:                                 bi.Vendor,
+		Version:                                bi.Version,
+		ReleaseDate:                            bi.ReleaseDate,
+		ROMSizeBytes:                           bi.GetROMSizeBytes(),
+		Characteristics:                        bi.Characteristics.Tokens(),
+		CharacteristicsExt1:                    bi.CharacteristicsExt1.Tokens(),
+		CharacteristicsExt2:                    bi.CharacteristicsExt2.Tokens(),
+		SystemBIOSMajorRelease:                 bi.SystemBIOSMajorRelease,
+		SystemBIOSMinorRelease:                 bi.SystemBIOSMinorRelease,
+		EmbeddedControllerFirmwareMajorRelease: bi.EmbeddedControllerFirmwareMajorRelease,
+		EmbeddedControllerFirmwareMinorRelease: bi.EmbeddedControllerFirmwareMinorRelease,
+	}
+}
+
+// biosCharacteristicsTokens maps BIOSCharacteristics bits to their stable
+// machine-readable tokens, in bit order. Bits 0-3 (reserved, unknown, and
+// characteristics not supported) and the int 13h floppy bits 20-25 have no
+// entry.
+var biosCharacteristicsTokens = []struct {
+	bit   BIOSCharacteristics
+	token string
+}{
+	{BIOSCharacteristicsISAIsSupported, "isa"},
+	{BIOSCharacteristicsMCAIsSupported, "mca"},
+	{BIOSCharacteristicsEISAIsSupported, "eisa"},
+	{BIOSCharacteristicsPCIIsSupported, "pci"},
+	{BIOSCharacteristicsPCCardPCMCIAIsSupported, "pcmcia"},
+	{BIOSCharacteristicsPlugAndPlayIsSupported, "plug_and_play"},
+	{BIOSCharacteristicsAPMIsSupported, "apm"},
+	{BIOSCharacteristicsBIOSIsUpgradeableFlash, "upgradeable"},
+	{BIOSCharacteristicsBIOSShadowingIsAllowed, "shadowing"},
+	{BIOSCharacteristicsVLVESAIsSupported, "vlb"},
+	{BIOSCharacteristicsESCDSupportIsAvailable, "escd"},
+	{BIOSCharacteristicsBootFromCDIsSupported, "boot_from_cd"},
+	{BIOSCharacteristicsSelectableBootIsSupported, "selectable_boot"},
+	{BIOSCharacteristicsBIOSROMIsSocketed, "socketed_rom"},
+	{BIOSCharacteristicsBootFromPCCardPCMCIAIsSupported, "boot_from_pcmcia"},
+	{BIOSCharacteristicsEDDSpecificationIsSupported, "edd"},
+	{BIOSCharacteristicsInt5hPrintScreenServiceIsSupported, "print_screen"},
+	{BIOSCharacteristicsInt9h8042KeyboardServicesAreSupported, "keyboard_8042"},
+	{BIOSCharacteristicsInt14hSerialServicesAreSupported, "serial"},
+	{BIOSCharacteristicsInt17hPrinterServicesAreSupported, "printer"},
+	{BIOSCharacteristicsInt10hCGAMonoVideoServicesAreSupported, "cga_mono_video"},
+	{BIOSCharacteristicsNECPC98, "nec_pc98"},
+}
+
+// Tokens returns the stable machine-readable names of the set bits in v
+// that have an entry in biosCharacteristicsTokens, suitable for JSON/YAML
+// output or scripting, in place of the tab-indented text produced by
+// String(). Set bits without an entry are omitted.
+func (v BIOSCharacteristics) Tokens() []string {
+	var tokens []string
+	for _, e := range biosCharacteristicsTokens {
+		if v&e.bit != 0 {
+			tokens = append(tokens, e.token)
+		}
+	}
+	return tokens
+}
+
+var biosCharacteristicsExt1Tokens = []struct {
+	bit   BIOSCharacteristicsExt1
+	token string
+}{
+	{BIOSCharacteristicsExt1ACPIIsSupported, "acpi"},
+	{BIOSCharacteristicsExt1USBLegacyIsSupported, "usb_legacy"},
+	{BIOSCharacteristicsExt1AGPIsSupported, "agp"},
+	{BIOSCharacteristicsExt1I2OBootIsSupported, "i2o_boot"},
+	{BIOSCharacteristicsExt1LS120SuperDiskBootIsSupported, "ls120_boot"},
+	{BIOSCharacteristicsExt1ATAPIZIPDriveBootIsSupported, "atapi_zip_boot"},
+	{BIOSCharacteristicsExt11394BootIsSupported, "ieee1394_boot"},
+	{BIOSCharacteristicsExt1SmartBatteryIsSupported, "smart_battery"},
+}
+
+// Tokens returns the stable machine-readable names of every set bit in v.
+func (v BIOSCharacteristicsExt1) Tokens() []string {
+	var tokens []string
+	for _, e := range biosCharacteristicsExt1Tokens {
+		if v&e.bit != 0 {
+			tokens = append(tokens, e.token)
+		}
+	}
+	return tokens
+}
+
+var biosCharacteristicsExt2Tokens = []struct {
+	bit   BIOSCharacteristicsExt2
+	token string
+}{
+	{BIOSCharacteristicsExt2BIOSBootSpecificationIsSupported, "bios_boot_specification"},
+	{BIOSCharacteristicsExt2FunctionKeyinitiatedNetworkServiceBootIsSupported, "network_boot"},
+	{BIOSCharacteristicsExt2TargetedContentDistributionIsSupported, "targeted_content_distribution"},
+	{BIOSCharacteristicsExt2UEFISpecificationIsSupported, "uefi"},
+	{BIOSCharacteristicsExt2SMBIOSTableDescribesAVirtualMachine, "vm"},
+}
+
+// Tokens returns the stable machine-readable names of every set bit in v.
+func (v BIOSCharacteristicsExt2) Tokens() []string {
+	var tokens []string
+	for _, e := range biosCharacteristicsExt2Tokens {
+		if v&e.bit != 0 {
+			tokens = append(tokens, e.token)
+		}
+	}
+	return tokens
+}
+
+// MarshalJSON implements json.Marshaler, emitting every decoded table
+// keyed by name.
+func (i *Info) MarshalJSON() ([]byte, error) {
+	out := map[string]interface{}{}
+	if i.BIOS != nil {
+		out["bios"] = i.BIOS
+	}
+	return json.Marshal(out)
+}
@@ -0,0 +1,85 @@
+// Copyright 2016-2019 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package smbios
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Query extracts a single field from info by its dotted path, e.g.
+// "bios.vendor" or "bios.characteristics.uefi".
+//
+// Query returns the field formatted as a string, or an error if the path
+// does not resolve to a known field or the referenced table was not
+// present in info.
+func Query(info *Info, path string) (string, error) {
+	parts := strings.Split(path, ".")
+	if len(parts) == 0 {
+		return "", fmt.Errorf("empty query")
+	}
+	switch parts[0] {
+	case "bios":
+		if info.BIOS == nil {
+			return "", fmt.Errorf("no BIOS Information table present")
+		}
+		return queryBIOSInformation(info.BIOS, parts[1:])
+	default:
+		return "", fmt.Errorf("unknown table %q", parts[0])
+	}
+}
+
+// biosInformationFields maps the dotted field names addressable under
+// "bios." to accessors returning their string representation.
+var biosInformationFields = map[string]func(*BIOSInformation) string{
+	"vendor":                    func(bi *BIOSInformation) string { return bi.Vendor },
+	"version":                   func(bi *BIOSInformation) string { return bi.Version },
+	"release_date":              func(bi *BIOSInformation) string { return bi.ReleaseDate },
+	"rom_size_bytes":            func(bi *BIOSInformation) string { return strconv.FormatUint(uint64(bi.GetROMSizeBytes()), 10) },
+	"system_bios_major_release": func(bi *BIOSInformation) string { return strconv.Itoa(int(bi.SystemBIOSMajorRelease)) },
+	"system_bios_minor_release": func(bi *BIOSInformation) string { return strconv.Itoa(int(bi.SystemBIOSMinorRelease)) },
+}
+
+// biosCharacteristicNames maps each dotted name addressable under
+// "bios.characteristics." to its bit, across all three characteristics
+// fields. It is built from the same biosCharacteristicsTokens,
+// biosCharacteristicsExt1Tokens, and biosCharacteristicsExt2Tokens tables
+// that back BIOSCharacteristics.Tokens() and friends (marshal.go), so every
+// token Tokens() can emit is queryable by the same name.
+var biosCharacteristicNames = buildBIOSCharacteristicNames()
+
+func buildBIOSCharacteristicNames() map[string]func(*BIOSInformation) bool {
+	names := map[string]func(*BIOSInformation) bool{}
+	for _, e := range biosCharacteristicsTokens {
+		bit := e.bit
+		names[e.token] = func(bi *BIOSInformation) bool { return bi.Characteristics&bit != 0 }
+	}
+	for _, e := range biosCharacteristicsExt1Tokens {
+		bit := e.bit
+		names[e.token] = func(bi *BIOSInformation) bool { return bi.CharacteristicsExt1&bit != 0 }
+	}
+	for _, e := range biosCharacteristicsExt2Tokens {
+		bit := e.bit
+		names[e.token] = func(bi *BIOSInformation) bool { return bi.CharacteristicsExt2&bit != 0 }
+	}
+	return names
+}
+
+func queryBIOSInformation(bi *BIOSInformation, path []string) (string, error) {
+	if len(path) == 1 {
+		if f, ok := biosInformationFields[path[0]]; ok {
+			return f(bi), nil
+		}
+		return "", fmt.Errorf("unknown field %q", path[0])
+	}
+	if len(path) == 2 && path[0] == "characteristics" {
+		if f, ok := biosCharacteristicNames[path[1]]; ok {
+			return strconv.FormatBool(f(bi)), nil
+		}
+		return "", fmt.Errorf("unknown characteristic %q", path[1])
+	}
+	return "", fmt.Errorf("unknown field %q", strings.Join(path, "."))
+}
@@ -56,7 +56,9 @@ func NewBIOSInformation(t *Table) (*BIOSInformation, error) {
 	return bi, nil
 }
 
-// GetROMSizeBytes returns ROM size in bytes.
+// GetROMSizeBytes returns ROM size in bytes. If the size cannot be
+// determined — the reserved 0xFFFF ExtendedROMSize sentinel, or ROMSize==0xFF
+// with no ExtendedROMSize field present — it returns 0.
 func (bi *BIOSInformation) GetROMSizeBytes() uint {
 	if bi.ROMSize != 0xff {
 		return 65536 * (uint(bi.ROMSize) + 1)
@@ -65,7 +67,14 @@ func (bi *BIOSInformation) GetROMSizeBytes() uint {
 	if bi.Len() >= 0x1a {
 		extSize = uint(bi.ExtendedROMSize)
 	} else {
-		extSize = 0x10 // 16 MB
+		// ROMSize signals "see ExtendedROMSize", but there is no
+		// ExtendedROMSize field in this structure; the size is not
+		// determinable.
+		return 0
+	}
+	if extSize == 0xffff {
+		// Reserved sentinel per DSP0134 7.1: no size is reported.
+		return 0
 	}
 	unit := (extSize >> 14)
 	multiplier := uint(1)